@@ -0,0 +1,22 @@
+package webapps
+
+import "context"
+
+type contextKey struct{ name string }
+
+var initDataContextKey = &contextKey{"webapps-init-data"}
+
+// NewContext returns a copy of ctx carrying data, retrievable with
+// FromContext. It is mainly useful for middleware (see webapps/middleware)
+// that verifies initData once per request and wants to hand the result
+// downstream.
+func NewContext(ctx context.Context, data WebAppInitData) context.Context {
+	return context.WithValue(ctx, initDataContextKey, data)
+}
+
+// FromContext extracts the WebAppInitData previously stored with
+// NewContext, typically by webapps/middleware.Middleware.
+func FromContext(ctx context.Context) (WebAppInitData, bool) {
+	data, ok := ctx.Value(initDataContextKey).(WebAppInitData)
+	return data, ok
+}
@@ -0,0 +1,121 @@
+package webapps
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WebAppChat mirrors the "chat" field of Telegram WebApp initData, present
+// when the mini app was opened from an attachment menu inside a chat.
+type WebAppChat struct {
+	ID       int64  `json:"id"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Username string `json:"username,omitempty"`
+	PhotoURL string `json:"photo_url,omitempty"`
+}
+
+// WebAppInitData is the fully decoded form of Telegram WebApp initData,
+// covering every field defined by the spec rather than just "user".
+type WebAppInitData struct {
+	User         WebAppUser    `json:"user"`
+	Receiver     *WebAppUser   `json:"receiver,omitempty"`
+	Chat         *WebAppChat   `json:"chat,omitempty"`
+	ChatType     string        `json:"chat_type,omitempty"`
+	ChatInstance string        `json:"chat_instance,omitempty"`
+	StartParam   string        `json:"start_param,omitempty"`
+	CanSendAfter time.Duration `json:"can_send_after,omitempty"`
+	AuthDate     time.Time     `json:"auth_date"`
+	QueryID      string        `json:"query_id,omitempty"`
+	Hash         string        `json:"hash"`
+}
+
+// VerifyWebAppInitData validates telegramInitData and decodes every field
+// defined by the Telegram WebApp spec, not just the embedded user.
+func VerifyWebAppInitData(telegramInitData, token string) (WebAppInitData, error) {
+	params, hashValue, err := parseInitData(telegramInitData)
+	if err != nil {
+		return WebAppInitData{}, fmt.Errorf("parsing failed: %w", err)
+	}
+
+	if err := validateRequiredFields(params); err != nil {
+		return WebAppInitData{}, err
+	}
+
+	if err := validateAuthTimestamp(params["auth_date"]); err != nil {
+		return WebAppInitData{}, err
+	}
+
+	if err := validateDataSignature(params, hashValue, token); err != nil {
+		return WebAppInitData{}, err
+	}
+
+	return decodeInitData(params)
+}
+
+func decodeInitData(params map[string]string) (WebAppInitData, error) {
+	user, err := decodeUserData(params["user"])
+	if err != nil {
+		return WebAppInitData{}, err
+	}
+
+	data := WebAppInitData{
+		User:         user,
+		ChatType:     params["chat_type"],
+		ChatInstance: params["chat_instance"],
+		StartParam:   params["start_param"],
+		QueryID:      params["query_id"],
+		Hash:         params["hash"],
+	}
+
+	if raw := params["receiver"]; raw != "" {
+		receiver, err := decodeUserData(raw)
+		if err != nil {
+			return WebAppInitData{}, fmt.Errorf("decoding receiver failed: %w", err)
+		}
+		data.Receiver = &receiver
+	}
+
+	if raw := params["chat"]; raw != "" {
+		chat, err := decodeChatData(raw)
+		if err != nil {
+			return WebAppInitData{}, fmt.Errorf("decoding chat failed: %w", err)
+		}
+		data.Chat = &chat
+	}
+
+	if raw := params["auth_date"]; raw != "" {
+		authTimestamp, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return WebAppInitData{}, fmt.Errorf("%w: %v", ErrAuthDateInvalid, err)
+		}
+		data.AuthDate = time.Unix(authTimestamp, 0)
+	}
+
+	if raw := params["can_send_after"]; raw != "" {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return WebAppInitData{}, fmt.Errorf("invalid can_send_after: %v", err)
+		}
+		data.CanSendAfter = time.Duration(seconds) * time.Second
+	}
+
+	return data, nil
+}
+
+func decodeChatData(encodedData string) (WebAppChat, error) {
+	decodedData, err := url.QueryUnescape(encodedData)
+	if err != nil {
+		return WebAppChat{}, fmt.Errorf("url unescape failed: %w", err)
+	}
+
+	var chat WebAppChat
+	if err := json.Unmarshal([]byte(decodedData), &chat); err != nil {
+		return chat, fmt.Errorf("json decode failed: %w", err)
+	}
+
+	return chat, nil
+}
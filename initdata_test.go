@@ -0,0 +1,114 @@
+package webapps
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestDecodeChatData(t *testing.T) {
+	tests := []struct {
+		name        string
+		encodedData string
+		want        WebAppChat
+		expectError bool
+	}{
+		{
+			name:        "Valid chat data",
+			encodedData: url.QueryEscape(`{"id":-100123456,"type":"group","title":"Test Chat"}`),
+			want: WebAppChat{
+				ID:    -100123456,
+				Type:  "group",
+				Title: "Test Chat",
+			},
+			expectError: false,
+		},
+		{
+			name:        "Invalid JSON format",
+			encodedData: url.QueryEscape("{invalid-json}"),
+			want:        WebAppChat{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeChatData(tt.encodedData)
+
+			if (err != nil) != tt.expectError {
+				t.Errorf("decodeChatData() error = %v, expectError %v", err, tt.expectError)
+				return
+			}
+
+			if !tt.expectError && got != tt.want {
+				t.Errorf("decodeChatData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyWebAppInitData_Integration(t *testing.T) {
+	token := "test_token_12345"
+	user := WebAppUser{ID: 12345, FirstName: "Test", Username: "testuser"}
+	userJSON, _ := json.Marshal(user)
+	chat := WebAppChat{ID: -100987654, Type: "supergroup", Title: "Test Group"}
+	chatJSON, _ := json.Marshal(chat)
+
+	authDate := fmt.Sprintf("%d", time.Now().Unix())
+
+	params := map[string]string{
+		"user":           string(userJSON),
+		"chat":           string(chatJSON),
+		"chat_type":      "supergroup",
+		"chat_instance":  "987654321",
+		"start_param":    "ref_42",
+		"can_send_after": "30",
+		"query_id":       "AAHdF6IQAAAAAN0XohDhrOrc",
+		"auth_date":      authDate,
+	}
+
+	dataCheckString := createDataCheckString(params, authDate)
+	hash := computeHMAC(dataCheckString, token)
+
+	initData := "auth_date=" + authDate +
+		"&user=" + url.QueryEscape(string(userJSON)) +
+		"&chat=" + url.QueryEscape(string(chatJSON)) +
+		"&chat_type=" + params["chat_type"] +
+		"&chat_instance=" + params["chat_instance"] +
+		"&start_param=" + params["start_param"] +
+		"&can_send_after=" + params["can_send_after"] +
+		"&query_id=" + params["query_id"] +
+		"&hash=" + hash
+
+	got, err := VerifyWebAppInitData(initData, token)
+	if err != nil {
+		t.Fatalf("VerifyWebAppInitData() error = %v", err)
+	}
+
+	if got.User.ID != user.ID {
+		t.Errorf("VerifyWebAppInitData() User = %v, want %v", got.User, user)
+	}
+
+	if got.Chat == nil || got.Chat.ID != chat.ID || got.Chat.Title != chat.Title {
+		t.Errorf("VerifyWebAppInitData() Chat = %v, want %v", got.Chat, chat)
+	}
+
+	if got.ChatType != "supergroup" || got.ChatInstance != "987654321" || got.StartParam != "ref_42" {
+		t.Errorf("VerifyWebAppInitData() chat fields = %+v", got)
+	}
+
+	if got.CanSendAfter != 30*time.Second {
+		t.Errorf("VerifyWebAppInitData() CanSendAfter = %v, want %v", got.CanSendAfter, 30*time.Second)
+	}
+
+	wantAuthDate, err := strconv.ParseInt(authDate, 10, 64)
+	if err != nil {
+		t.Fatalf("parsing authDate fixture: %v", err)
+	}
+	if got.AuthDate.Unix() != wantAuthDate {
+		t.Errorf("VerifyWebAppInitData() AuthDate = %v, want unix %v", got.AuthDate, wantAuthDate)
+	}
+}
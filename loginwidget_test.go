@@ -0,0 +1,98 @@
+package webapps
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestVerifyLoginWidget_Integration(t *testing.T) {
+	token := "test_bot_token"
+	authDate := fmt.Sprintf("%d", time.Now().Unix())
+
+	data := map[string]string{
+		"id":         "12345",
+		"first_name": "Ada",
+		"last_name":  "Lovelace",
+		"username":   "ada",
+		"auth_date":  authDate,
+	}
+
+	dataCheckString := createDataCheckString(data, authDate)
+	data["hash"] = loginWidgetHMAC(dataCheckString, token)
+
+	user, err := VerifyLoginWidget(data, token)
+	if err != nil {
+		t.Fatalf("VerifyLoginWidget() error = %v", err)
+	}
+
+	if user.ID != 12345 || user.Username != "ada" {
+		t.Errorf("VerifyLoginWidget() = %+v", user)
+	}
+
+	data["hash"] = "tampered"
+	if _, err := VerifyLoginWidget(data, token); err != ErrInvalidHash {
+		t.Errorf("VerifyLoginWidget() with tampered hash error = %v, want %v", err, ErrInvalidHash)
+	}
+}
+
+func TestVerifyLoginWidget_MissingFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		wantErr error
+	}{
+		{
+			name:    "Missing hash",
+			data:    map[string]string{"id": "1", "auth_date": "1625097522"},
+			wantErr: ErrInvalidHash,
+		},
+		{
+			name:    "Missing id",
+			data:    map[string]string{"hash": "abc", "auth_date": "1625097522"},
+			wantErr: ErrUserFieldMissing,
+		},
+		{
+			name:    "Missing auth_date",
+			data:    map[string]string{"hash": "abc", "id": "1"},
+			wantErr: ErrAuthDateMissing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyLoginWidget(tt.data, "token")
+			if err != tt.wantErr {
+				t.Errorf("VerifyLoginWidget() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyLoginWidgetQuery(t *testing.T) {
+	token := "test_bot_token"
+	authDate := fmt.Sprintf("%d", time.Now().Unix())
+
+	data := map[string]string{
+		"id":        "54321",
+		"username":  "grace",
+		"auth_date": authDate,
+	}
+	dataCheckString := createDataCheckString(data, authDate)
+	data["hash"] = loginWidgetHMAC(dataCheckString, token)
+
+	values := url.Values{}
+	for k, v := range data {
+		values.Set(k, v)
+	}
+
+	user, err := VerifyLoginWidgetQuery(values, token)
+	if err != nil {
+		t.Fatalf("VerifyLoginWidgetQuery() error = %v", err)
+	}
+
+	if user.ID != 54321 || user.Username != "grace" {
+		t.Errorf("VerifyLoginWidgetQuery() = %+v", user)
+	}
+}
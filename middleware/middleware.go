@@ -0,0 +1,146 @@
+// Package middleware provides net/http middleware that guards mini-app
+// backend endpoints by verifying Telegram WebApp initData on every request.
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	webapps "github.com/Fuchsoria/telegram-webapps"
+)
+
+// ErrorCode identifies why a request was rejected, so callers can react
+// programmatically instead of matching on error message text.
+type ErrorCode string
+
+const (
+	ErrCodeMissingInitData ErrorCode = "missing_init_data"
+	ErrCodeInvalidHash     ErrorCode = "invalid_hash"
+	ErrCodeExpired         ErrorCode = "expired"
+	ErrCodeMissingUser     ErrorCode = "missing_user"
+	ErrCodeInvalidFormat   ErrorCode = "invalid_format"
+)
+
+// Logger is the subset of logging behavior Middleware needs, satisfied by
+// *log.Logger among others, so callers aren't forced onto a specific
+// logging package.
+type Logger interface {
+	Printf(format string, args ...any)
+}
+
+type config struct {
+	headerName string
+	logger     Logger
+	skip       map[string]bool
+}
+
+// Option configures Middleware.
+type Option func(*config)
+
+// WithHeaderName overrides the header Middleware falls back to when
+// initData isn't supplied via "Authorization: tma <initData>". The default
+// is X-Telegram-Init-Data.
+func WithHeaderName(name string) Option {
+	return func(c *config) {
+		c.headerName = name
+	}
+}
+
+// WithLogger attaches a logger used to report rejected requests.
+func WithLogger(logger Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithSkipPaths exempts the given request paths from verification, e.g.
+// health checks.
+func WithSkipPaths(paths ...string) Option {
+	return func(c *config) {
+		for _, p := range paths {
+			c.skip[p] = true
+		}
+	}
+}
+
+type errorResponse struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// Middleware verifies initData supplied by a Telegram mini-app client and,
+// on success, injects the decoded webapps.WebAppInitData into the request
+// context, retrievable via webapps.FromContext. initData is read from the
+// "Authorization: tma <initData>" header first, falling back to the header
+// configured by WithHeaderName (X-Telegram-Init-Data by default).
+func Middleware(token string, opts ...Option) func(http.Handler) http.Handler {
+	cfg := &config{
+		headerName: "X-Telegram-Init-Data",
+		skip:       make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if cfg.skip[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			raw := extractInitData(r, cfg.headerName)
+			if raw == "" {
+				writeError(w, cfg, http.StatusUnauthorized, ErrCodeMissingInitData, "missing init data")
+				return
+			}
+
+			data, err := webapps.VerifyWebAppInitData(raw, token)
+			if err != nil {
+				code, status := classifyError(err)
+				writeError(w, cfg, status, code, err.Error())
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(webapps.NewContext(r.Context(), data)))
+		})
+	}
+}
+
+func extractInitData(r *http.Request, headerName string) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if rest, ok := strings.CutPrefix(auth, "tma "); ok {
+			return rest
+		}
+	}
+
+	return r.Header.Get(headerName)
+}
+
+func classifyError(err error) (ErrorCode, int) {
+	switch {
+	case errors.Is(err, webapps.ErrDataExpired):
+		return ErrCodeExpired, http.StatusUnauthorized
+	case errors.Is(err, webapps.ErrUserFieldMissing):
+		return ErrCodeMissingUser, http.StatusBadRequest
+	case errors.Is(err, webapps.ErrInvalidHash),
+		errors.Is(err, webapps.ErrInvalidSignature),
+		errors.Is(err, webapps.ErrMissingSignature),
+		errors.Is(err, webapps.ErrUnknownBotPublicKey):
+		return ErrCodeInvalidHash, http.StatusUnauthorized
+	default:
+		return ErrCodeInvalidFormat, http.StatusBadRequest
+	}
+}
+
+func writeError(w http.ResponseWriter, cfg *config, status int, code ErrorCode, message string) {
+	if cfg.logger != nil {
+		cfg.logger.Printf("webapps middleware: rejected request: %s: %s", code, message)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Code: code, Message: message})
+}
@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	webapps "github.com/Fuchsoria/telegram-webapps"
+)
+
+// signInitData mirrors the package's own HMAC("WebAppData", token) scheme
+// so tests can construct initData a real Telegram client would send.
+func signInitData(authDate, userJSON, token string) string {
+	dataCheckString := "auth_date=" + authDate + "\nuser=" + userJSON
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(token))
+
+	h := hmac.New(sha256.New, secret.Sum(nil))
+	h.Write([]byte(dataCheckString))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestMiddleware_MissingInitData(t *testing.T) {
+	handler := Middleware("test_token")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler should not be called")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	var body errorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+
+	if body.Code != ErrCodeMissingInitData {
+		t.Errorf("code = %v, want %v", body.Code, ErrCodeMissingInitData)
+	}
+}
+
+func TestMiddleware_SkipPaths(t *testing.T) {
+	called := false
+	handler := Middleware("test_token", WithSkipPaths("/healthz"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if !called {
+		t.Error("handler should have been called for a skipped path")
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestExtractInitData(t *testing.T) {
+	tests := []struct {
+		name       string
+		authHeader string
+		rawHeader  string
+		want       string
+	}{
+		{
+			name:       "Authorization tma scheme",
+			authHeader: "tma auth_date=1&user=%7B%7D&hash=abc",
+			want:       "auth_date=1&user=%7B%7D&hash=abc",
+		},
+		{
+			name:      "Fallback header",
+			rawHeader: "auth_date=1&user=%7B%7D&hash=abc",
+			want:      "auth_date=1&user=%7B%7D&hash=abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			if tt.rawHeader != "" {
+				req.Header.Set("X-Telegram-Init-Data", tt.rawHeader)
+			}
+
+			got := extractInitData(req, "X-Telegram-Init-Data")
+			if got != tt.want {
+				t.Errorf("extractInitData() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMiddleware_ValidInitData(t *testing.T) {
+	token := "test_token_12345"
+	user := webapps.WebAppUser{ID: 42, FirstName: "Ada"}
+	userJSON, _ := json.Marshal(user)
+	authDate := fmt.Sprintf("%d", time.Now().Unix())
+
+	hash := signInitData(authDate, string(userJSON), token)
+	initData := "auth_date=" + authDate + "&user=" + url.QueryEscape(string(userJSON)) + "&hash=" + hash
+
+	var gotInitData webapps.WebAppInitData
+	handler := Middleware(token)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotInitData, _ = webapps.FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "tma "+initData)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	if gotInitData.User.ID != user.ID {
+		t.Errorf("FromContext() user = %v, want %v", gotInitData.User, user)
+	}
+}
@@ -0,0 +1,132 @@
+package webapps
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrReplay is returned by Verifier.Verify when a NonceStore reports that
+// the same initData has already been verified once and WithMaxDataAge (or
+// the TTL derived from it) has not yet elapsed.
+var ErrReplay = errors.New("initData already used")
+
+// NonceStore records initData that has already been verified, so it can be
+// rejected if presented again. Seen reports whether key was already present
+// and, if not, records it with the given ttl.
+type NonceStore interface {
+	Seen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// nonceKey derives the NonceStore key for a verified initData's parameters:
+// sha256 of its hash, auth_date and query_id.
+func nonceKey(params map[string]string) string {
+	sum := sha256.Sum256([]byte(params["hash"] + params["auth_date"] + params["query_id"]))
+	return hex.EncodeToString(sum[:])
+}
+
+type nonceEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// MemoryNonceStore is an in-memory NonceStore bounded by insertion order
+// (FIFO), not recency of access: a repeated Seen on an existing key reports
+// it as seen without moving it, so it still expires and gets evicted on its
+// original schedule. It is safe for concurrent use but does not survive
+// process restarts or scale beyond a single instance; use RedisNonceStore
+// for a shared deployment.
+type MemoryNonceStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+// NewMemoryNonceStore creates a MemoryNonceStore holding at most capacity
+// entries, evicting the oldest by insertion order once full. capacity <= 0
+// means unbounded.
+func NewMemoryNonceStore(capacity int) *MemoryNonceStore {
+	return &MemoryNonceStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (s *MemoryNonceStore) Seen(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.evictExpired(now)
+
+	if el, ok := s.entries[key]; ok {
+		entry := el.Value.(*nonceEntry)
+		if entry.expiresAt.After(now) {
+			return true, nil
+		}
+		s.order.Remove(el)
+		delete(s.entries, key)
+	}
+
+	s.entries[key] = s.order.PushFront(&nonceEntry{key: key, expiresAt: now.Add(ttl)})
+
+	if s.capacity > 0 {
+		for s.order.Len() > s.capacity {
+			oldest := s.order.Back()
+			if oldest == nil {
+				break
+			}
+			s.order.Remove(oldest)
+			delete(s.entries, oldest.Value.(*nonceEntry).key)
+		}
+	}
+
+	return false, nil
+}
+
+func (s *MemoryNonceStore) evictExpired(now time.Time) {
+	for {
+		el := s.order.Back()
+		if el == nil {
+			break
+		}
+		if el.Value.(*nonceEntry).expiresAt.After(now) {
+			break
+		}
+		s.order.Remove(el)
+		delete(s.entries, el.Value.(*nonceEntry).key)
+	}
+}
+
+// RedisClient is the subset of a Redis client's behavior RedisNonceStore
+// needs, kept minimal so this package doesn't depend on a specific Redis
+// library. SetNX should set key to a placeholder value with the given ttl
+// and report whether the key did not already exist.
+type RedisClient interface {
+	SetNX(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// RedisNonceStore adapts a RedisClient to NonceStore, suitable for sharing
+// replay state across multiple backend instances.
+type RedisNonceStore struct {
+	client RedisClient
+}
+
+// NewRedisNonceStore creates a RedisNonceStore backed by client.
+func NewRedisNonceStore(client RedisClient) *RedisNonceStore {
+	return &RedisNonceStore{client: client}
+}
+
+func (s *RedisNonceStore) Seen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	acquired, err := s.client.SetNX(ctx, key, ttl)
+	if err != nil {
+		return false, err
+	}
+	return !acquired, nil
+}
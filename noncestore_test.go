@@ -0,0 +1,115 @@
+package webapps
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMemoryNonceStore_Seen(t *testing.T) {
+	store := NewMemoryNonceStore(0)
+	ctx := context.Background()
+
+	seen, err := store.Seen(ctx, "key1", time.Minute)
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+	if seen {
+		t.Error("Seen() = true on first use, want false")
+	}
+
+	seen, err = store.Seen(ctx, "key1", time.Minute)
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+	if !seen {
+		t.Error("Seen() = false on second use, want true")
+	}
+}
+
+func TestMemoryNonceStore_Expiry(t *testing.T) {
+	store := NewMemoryNonceStore(0)
+	ctx := context.Background()
+
+	if _, err := store.Seen(ctx, "key1", -time.Second); err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+
+	seen, err := store.Seen(ctx, "key1", time.Minute)
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+	if seen {
+		t.Error("Seen() = true for an already-expired entry, want false")
+	}
+}
+
+func TestMemoryNonceStore_Capacity(t *testing.T) {
+	store := NewMemoryNonceStore(1)
+	ctx := context.Background()
+
+	if _, err := store.Seen(ctx, "key1", time.Minute); err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+	if _, err := store.Seen(ctx, "key2", time.Minute); err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+
+	seen, err := store.Seen(ctx, "key1", time.Minute)
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+	if seen {
+		t.Error("Seen() = true for key evicted by capacity limit, want false")
+	}
+}
+
+type stubNonceStore struct {
+	seen map[string]bool
+}
+
+func (s *stubNonceStore) Seen(_ context.Context, key string, _ time.Duration) (bool, error) {
+	if s.seen[key] {
+		return true, nil
+	}
+	if s.seen == nil {
+		s.seen = make(map[string]bool)
+	}
+	s.seen[key] = true
+	return false, nil
+}
+
+func TestVerifier_ReplayProtection(t *testing.T) {
+	token := "test_token_12345"
+	fixedNow := time.Unix(1700000000, 0)
+
+	initData := signedInitDataForTest(t, token, fixedNow.Unix())
+
+	v := NewVerifier(token,
+		WithClock(func() time.Time { return fixedNow }),
+		WithNonceStore(&stubNonceStore{}),
+	)
+
+	if _, err := v.Verify(initData); err != nil {
+		t.Fatalf("Verify() first use error = %v", err)
+	}
+
+	if _, err := v.Verify(initData); err != ErrReplay {
+		t.Errorf("Verify() second use error = %v, want %v", err, ErrReplay)
+	}
+}
+
+func signedInitDataForTest(t *testing.T, token string, authDateUnix int64) string {
+	t.Helper()
+
+	params := map[string]string{
+		"user":      `{"id":12345}`,
+		"auth_date": strconv.FormatInt(authDateUnix, 10),
+	}
+
+	dataCheckString := createDataCheckString(params, params["auth_date"])
+	hash := computeHMAC(dataCheckString, token)
+
+	return "auth_date=" + params["auth_date"] + "&user=" + params["user"] + "&hash=" + hash
+}
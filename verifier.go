@@ -0,0 +1,276 @@
+package webapps
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// discardLogger is the default Verifier logger: it emits nothing, so
+// constructing a Verifier without WithLogger costs nothing at runtime.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+var (
+	ErrMissingSignature    = errors.New("signature field missing")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrUnknownBotPublicKey = errors.New("no public key registered for bot")
+)
+
+// Option configures a Verifier created via NewVerifier.
+type Option func(*Verifier)
+
+// WithMaxDataAge overrides how old initData is allowed to be before it is
+// rejected as expired. The default is MaxDataAge.
+func WithMaxDataAge(d time.Duration) Option {
+	return func(v *Verifier) {
+		v.maxDataAge = d
+	}
+}
+
+// WithClock overrides the function used to obtain the current time, mainly
+// useful in tests that need deterministic auth_date comparisons.
+func WithClock(clock func() time.Time) Option {
+	return func(v *Verifier) {
+		v.clock = clock
+	}
+}
+
+// WithThirdPartyValidation switches the Verifier from the standard
+// HMAC("WebAppData", token) scheme to Telegram's third-party mini-app flow,
+// where initData is signed with Ed25519 by Telegram on behalf of botID and
+// can be checked without holding that bot's token. The corresponding public
+// key must be registered beforehand via RegisterBotPublicKey.
+func WithThirdPartyValidation(botID int64) Option {
+	return func(v *Verifier) {
+		v.thirdParty = true
+		v.thirdPartyBotID = botID
+	}
+}
+
+// WithNonceStore enables replay protection: each verified initData is
+// recorded in store keyed on its hash, auth_date and query_id, and a second
+// presentation of the same initData fails with ErrReplay. Without this
+// option, initData can be replayed until it expires per WithMaxDataAge.
+func WithNonceStore(store NonceStore) Option {
+	return func(v *Verifier) {
+		v.nonceStore = store
+	}
+}
+
+// WithLogger attaches a *slog.Logger that Verifier emits DEBUG-level events
+// to for each verification stage (parse, required-fields, timestamp,
+// signature, decode). Logged fields never include raw hash or token
+// material, only lengths and outcomes. The default logger discards
+// everything.
+func WithLogger(logger *slog.Logger) Option {
+	return func(v *Verifier) {
+		if logger != nil {
+			v.logger = logger
+		}
+	}
+}
+
+// WithSignatureFailureCallback registers a hook invoked with the offending
+// params whenever signature validation fails, so operators can plug in
+// metrics or alerting for brute-force attempts without patching this
+// package.
+func WithSignatureFailureCallback(cb func(params map[string]string)) Option {
+	return func(v *Verifier) {
+		v.signatureFailureCallback = cb
+	}
+}
+
+// Verifier validates Telegram WebApp initData. Use NewVerifier to construct
+// one; the zero value is not usable. Verifier supersedes the package-level
+// VerifyWebAppData function, which does not use a Verifier: it wraps
+// VerifyWebAppInitData and always applies the default MaxDataAge.
+type Verifier struct {
+	token      string
+	maxDataAge time.Duration
+	clock      func() time.Time
+
+	thirdParty      bool
+	thirdPartyBotID int64
+
+	nonceStore NonceStore
+
+	logger                   *slog.Logger
+	signatureFailureCallback func(params map[string]string)
+}
+
+// NewVerifier creates a Verifier for the given bot token, applying any
+// options in order.
+func NewVerifier(token string, opts ...Option) *Verifier {
+	v := &Verifier{
+		token:      token,
+		maxDataAge: MaxDataAge,
+		clock:      time.Now,
+		logger:     discardLogger,
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Verify validates telegramInitData and returns the embedded WebAppUser.
+func (v *Verifier) Verify(telegramInitData string) (WebAppUser, error) {
+	return v.VerifyContext(context.Background(), telegramInitData)
+}
+
+// VerifyContext is like Verify but threads ctx through to the configured
+// NonceStore, if any.
+func (v *Verifier) VerifyContext(ctx context.Context, telegramInitData string) (WebAppUser, error) {
+	params, hashValue, err := parseInitData(telegramInitData)
+	if err != nil {
+		v.logger.Debug("webapps: parse", "length", len(telegramInitData), "ok", false)
+		return WebAppUser{}, fmt.Errorf("parsing failed: %w", err)
+	}
+	v.logger.Debug("webapps: parse", "length", len(telegramInitData), "ok", true, "fields", len(params))
+
+	if err := validateRequiredFields(params); err != nil {
+		v.logger.Debug("webapps: required-fields", "ok", false)
+		return WebAppUser{}, err
+	}
+	v.logger.Debug("webapps: required-fields", "ok", true)
+
+	if err := v.validateTimestamp(params["auth_date"]); err != nil {
+		v.logger.Debug("webapps: timestamp", "ok", false)
+		return WebAppUser{}, err
+	}
+	v.logger.Debug("webapps: timestamp", "ok", true)
+
+	if v.thirdParty {
+		if err := v.validateThirdPartySignature(params); err != nil {
+			v.logger.Debug("webapps: signature", "mode", "third_party", "ok", false)
+			v.reportSignatureFailure(params)
+			return WebAppUser{}, err
+		}
+	} else if err := validateDataSignature(params, hashValue, v.token); err != nil {
+		v.logger.Debug("webapps: signature", "mode", "hmac", "ok", false)
+		v.reportSignatureFailure(params)
+		return WebAppUser{}, err
+	}
+	v.logger.Debug("webapps: signature", "ok", true)
+
+	if v.nonceStore != nil {
+		if err := v.checkReplay(ctx, params); err != nil {
+			v.logger.Debug("webapps: replay", "ok", false)
+			return WebAppUser{}, err
+		}
+		v.logger.Debug("webapps: replay", "ok", true)
+	}
+
+	user, err := decodeUserData(params["user"])
+	if err != nil {
+		v.logger.Debug("webapps: decode", "ok", false)
+		return WebAppUser{}, err
+	}
+	v.logger.Debug("webapps: decode", "ok", true)
+
+	return user, nil
+}
+
+func (v *Verifier) reportSignatureFailure(params map[string]string) {
+	if v.signatureFailureCallback != nil {
+		v.signatureFailureCallback(params)
+	}
+}
+
+func (v *Verifier) checkReplay(ctx context.Context, params map[string]string) error {
+	seen, err := v.nonceStore.Seen(ctx, nonceKey(params), v.maxDataAge)
+	if err != nil {
+		return fmt.Errorf("nonce store: %w", err)
+	}
+	if seen {
+		return ErrReplay
+	}
+	return nil
+}
+
+func (v *Verifier) validateTimestamp(authDateStr string) error {
+	authTimestamp, err := strconv.ParseInt(authDateStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAuthDateInvalid, err)
+	}
+
+	authTime := time.Unix(authTimestamp, 0)
+	if v.clock().Sub(authTime) > v.maxDataAge {
+		return ErrDataExpired
+	}
+
+	return nil
+}
+
+func (v *Verifier) validateThirdPartySignature(params map[string]string) error {
+	signature := params["signature"]
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	pub, ok := lookupBotPublicKey(v.thirdPartyBotID)
+	if !ok {
+		return ErrUnknownBotPublicKey
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	dataCheckString := createThirdPartyDataCheckString(params, v.thirdPartyBotID)
+	if !ed25519.Verify(pub, []byte(dataCheckString), sig) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// createThirdPartyDataCheckString builds the data-check-string used for
+// Telegram's third-party mini-app signature scheme: every field except hash
+// and signature, sorted and joined the same way as createDataCheckString,
+// prefixed with "<bot_id>:WebAppData".
+func createThirdPartyDataCheckString(params map[string]string, botID int64) string {
+	pairs := make([]string, 0, len(params))
+	for k, val := range params {
+		if k == "hash" || k == "signature" {
+			continue
+		}
+		pairs = append(pairs, k+"="+val)
+	}
+	sort.Strings(pairs)
+
+	return fmt.Sprintf("%d:WebAppData\n%s", botID, strings.Join(pairs, "\n"))
+}
+
+var (
+	botPublicKeysMu sync.RWMutex
+	botPublicKeys   = make(map[int64]ed25519.PublicKey)
+)
+
+// RegisterBotPublicKey registers the Ed25519 public key Telegram uses to
+// sign third-party initData on behalf of botID. It must be called before a
+// Verifier configured with WithThirdPartyValidation(botID) can succeed.
+func RegisterBotPublicKey(botID int64, pub ed25519.PublicKey) {
+	botPublicKeysMu.Lock()
+	defer botPublicKeysMu.Unlock()
+	botPublicKeys[botID] = pub
+}
+
+func lookupBotPublicKey(botID int64) (ed25519.PublicKey, bool) {
+	botPublicKeysMu.RLock()
+	defer botPublicKeysMu.RUnlock()
+	pub, ok := botPublicKeys[botID]
+	return pub, ok
+}
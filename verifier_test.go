@@ -0,0 +1,132 @@
+package webapps
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifier_Verify_Integration(t *testing.T) {
+	token := "test_token_12345"
+	user := WebAppUser{ID: 777, FirstName: "Grace"}
+	userJSON, _ := json.Marshal(user)
+	authDate := fmt.Sprintf("%d", time.Now().Unix())
+
+	params := map[string]string{
+		"user":      string(userJSON),
+		"auth_date": authDate,
+	}
+	dataCheckString := createDataCheckString(params, authDate)
+	hash := computeHMAC(dataCheckString, token)
+
+	initData := "auth_date=" + authDate + "&user=" + string(userJSON) + "&hash=" + hash
+
+	v := NewVerifier(token)
+	got, err := v.Verify(initData)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got.ID != user.ID {
+		t.Errorf("Verify() = %v, want %v", got, user)
+	}
+}
+
+func TestVerifier_WithLogger_NoSecretsLeaked(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	token := "super-secret-token"
+	v := NewVerifier(token, WithLogger(logger))
+
+	if _, err := v.Verify("not=validquery&hash=abc"); err == nil {
+		t.Fatal("Verify() expected error for malformed/missing fields")
+	}
+
+	output := buf.String()
+	if strings.Contains(output, token) {
+		t.Error("logger output leaked the bot token")
+	}
+}
+
+func TestVerifier_WithSignatureFailureCallback(t *testing.T) {
+	var gotParams map[string]string
+	v := NewVerifier("token", WithSignatureFailureCallback(func(params map[string]string) {
+		gotParams = params
+	}))
+
+	authDate := fmt.Sprintf("%d", time.Now().Unix())
+	initData := "auth_date=" + authDate + `&user={"id":1}` + "&hash=wrong"
+
+	if _, err := v.Verify(initData); err == nil {
+		t.Fatal("Verify() expected signature error")
+	}
+
+	if gotParams == nil {
+		t.Fatal("signature failure callback was not invoked")
+	}
+	if gotParams["auth_date"] != authDate {
+		t.Errorf("callback params auth_date = %v, want %v", gotParams["auth_date"], authDate)
+	}
+}
+
+func TestVerifier_ThirdPartyValidation(t *testing.T) {
+	const botID int64 = 987654321
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	RegisterBotPublicKey(botID, pub)
+
+	user := WebAppUser{ID: 42, FirstName: "Ada"}
+	userJSON, _ := json.Marshal(user)
+	authDate := fmt.Sprintf("%d", time.Now().Unix())
+
+	params := map[string]string{
+		"user":      string(userJSON),
+		"auth_date": authDate,
+		"hash":      "unused-in-third-party-flow",
+	}
+	dataCheckString := createThirdPartyDataCheckString(params, botID)
+	sig := ed25519.Sign(priv, []byte(dataCheckString))
+	signature := base64.RawURLEncoding.EncodeToString(sig)
+
+	initData := "auth_date=" + authDate +
+		"&user=" + string(userJSON) +
+		"&hash=" + params["hash"] +
+		"&signature=" + signature
+
+	v := NewVerifier("", WithThirdPartyValidation(botID))
+
+	got, err := v.Verify(initData)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got.ID != user.ID {
+		t.Errorf("Verify() = %v, want %v", got, user)
+	}
+
+	tamperedSig := make([]byte, len(sig))
+	copy(tamperedSig, sig)
+	tamperedSig[0] ^= 0xFF
+	tamperedSignature := base64.RawURLEncoding.EncodeToString(tamperedSig)
+	tampered := "auth_date=" + authDate +
+		"&user=" + string(userJSON) +
+		"&hash=" + params["hash"] +
+		"&signature=" + tamperedSignature
+	if _, err := v.Verify(tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() with tampered signature error = %v, want %v", err, ErrInvalidSignature)
+	}
+
+	vUnknownBot := NewVerifier("", WithThirdPartyValidation(botID+1))
+	if _, err := vUnknownBot.Verify(initData); !errors.Is(err, ErrUnknownBotPublicKey) {
+		t.Errorf("Verify() for unregistered bot error = %v, want %v", err, ErrUnknownBotPublicKey)
+	}
+}
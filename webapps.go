@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"sort"
 	"strconv"
@@ -41,25 +40,16 @@ type WebAppUser struct {
 	PhotoURL              string `json:"photo_url"`
 }
 
+// VerifyWebAppData validates telegramInitData and returns the embedded
+// user. It is a thin wrapper around VerifyWebAppInitData for callers who
+// only need the user and not the rest of the initData payload.
 func VerifyWebAppData(telegramInitData, token string) (WebAppUser, error) {
-	params, hashValue, err := parseInitData(telegramInitData)
+	data, err := VerifyWebAppInitData(telegramInitData, token)
 	if err != nil {
-		return WebAppUser{}, fmt.Errorf("parsing failed: %w", err)
-	}
-
-	if err := validateRequiredFields(params); err != nil {
-		return WebAppUser{}, err
-	}
-
-	if err := validateAuthTimestamp(params["auth_date"]); err != nil {
-		return WebAppUser{}, err
-	}
-
-	if err := validateDataSignature(params, hashValue, token); err != nil {
 		return WebAppUser{}, err
 	}
 
-	return decodeUserData(params["user"])
+	return data.User, nil
 }
 
 func parseInitData(initData string) (map[string]string, string, error) {
@@ -106,7 +96,6 @@ func validateDataSignature(params map[string]string, receivedHash, token string)
 	dataCheckString := createDataCheckString(params, params["auth_date"])
 	expectedHash := computeHMAC(dataCheckString, token)
 
-	fmt.Println(expectedHash, receivedHash)
 	if !hmac.Equal([]byte(expectedHash), []byte(receivedHash)) {
 		return ErrInvalidHash
 	}
@@ -130,13 +119,24 @@ func createDataCheckString(params map[string]string, authDate string) string {
 }
 
 func computeHMAC(dataCheckString, token string) string {
+	return hmacHex(dataCheckString, webAppSecretKey(token))
+}
+
+// webAppSecretKey derives the HMAC key Telegram uses for Mini App initData:
+// HMAC-SHA256("WebAppData", token).
+func webAppSecretKey(token string) []byte {
 	secret := hmac.New(sha256.New, []byte("WebAppData"))
 	secret.Write([]byte(token))
+	return secret.Sum(nil)
+}
 
-	hHash := hmac.New(sha256.New, secret.Sum(nil))
-	hHash.Write([]byte(dataCheckString))
-
-	return hex.EncodeToString(hHash.Sum(nil))
+// hmacHex computes hex(HMAC-SHA256(key, dataCheckString)), the common final
+// step shared by the WebApp and Login Widget verification flows; only the
+// key derivation differs between them.
+func hmacHex(dataCheckString string, key []byte) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(dataCheckString))
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 func decodeUserData(encodedData string) (WebAppUser, error) {